@@ -0,0 +1,72 @@
+package logical
+
+import "fmt"
+
+// BatchPolicy controls how a batch request's sub-requests are dispatched
+// and how their failures are handled.
+type BatchPolicy string
+
+const (
+	// StopOnError dispatches sub-requests in order and stops at the first
+	// one that errors, leaving the remainder undispatched.
+	StopOnError BatchPolicy = "stop-on-error"
+
+	// ContinueOnError dispatches every sub-request regardless of earlier
+	// failures, collecting all results.
+	ContinueOnError BatchPolicy = "continue-on-error"
+
+	// AllOrNothing composes with the transaction subsystem: every
+	// sub-request is run under a single transaction that is committed
+	// only if all of them succeed, and rolled back otherwise.
+	AllOrNothing BatchPolicy = "all-or-nothing"
+)
+
+// BatchResult pairs a single sub-request's outcome with its index in
+// Request.Batch, so callers can match responses back to the request that
+// produced them.
+type BatchResult struct {
+	Index    int
+	Response *Response
+	Error    error
+}
+
+// BatchResponse is returned for a BatchOperation request. Results is
+// ordered to match Request.Batch.
+type BatchResponse struct {
+	Results []*BatchResult
+}
+
+// ValidateBatch checks the invariants a BatchOperation request must
+// satisfy before dispatch: it must carry sub-requests, none of those
+// sub-requests may themselves be a batch, and an AllOrNothing batch must
+// have every sub-request resolve under the same mount.
+//
+// sameMount is supplied by the caller (the router, which is the only
+// place that knows how a Path resolves to a mount) and is used to compare
+// every sub-request's resolved mount against the first one.
+func (r *Request) ValidateBatch(sameMount func(a, b *Request) bool) error {
+	if r.Operation != BatchOperation {
+		return nil
+	}
+
+	if len(r.Batch) == 0 {
+		return fmt.Errorf("%w: batch request with no sub-requests", ErrInvalidRequest)
+	}
+
+	for i, sub := range r.Batch {
+		if sub.Operation == BatchOperation {
+			return fmt.Errorf("%w: nested batch at index %d", ErrInvalidRequest, i)
+		}
+	}
+
+	if r.BatchPolicy == AllOrNothing {
+		first := r.Batch[0]
+		for i, sub := range r.Batch[1:] {
+			if !sameMount(first, sub) {
+				return fmt.Errorf("%w: all-or-nothing batch sub-request %d resolves to a different mount", ErrInvalidRequest, i+1)
+			}
+		}
+	}
+
+	return nil
+}
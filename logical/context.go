@@ -0,0 +1,69 @@
+package logical
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "request-id"
+
+// WithRequestID returns a copy of ctx carrying id as the request's stable
+// identifier, retrievable later via RequestID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID pulls the stable request ID set by WithRequestID out of ctx.
+// It returns the empty string if ctx carries none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// CheckDeadline returns an ErrRequestTimeout-wrapped error if ctx has
+// already been canceled or its deadline has passed. Dispatch points
+// (Read/Write/Delete/List/Renew/Revoke/Rollback) should call this before
+// handing a request to a backend.
+func CheckDeadline(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w: %s", ErrRequestTimeout, err)
+	}
+	return nil
+}
+
+// Middleware is invoked around every Read/Write/Delete/List/Renew/Revoke/
+// Rollback dispatch, letting operators plug in OpenTelemetry-style span
+// creation or other request-scoped instrumentation. It returns the
+// context that should be used for the remainder of the dispatch.
+type Middleware func(ctx context.Context, r *Request) context.Context
+
+var (
+	middlewareMu sync.RWMutex
+	middleware   []Middleware
+)
+
+// RegisterMiddleware adds mw to the chain run around every request
+// dispatch, in registration order. It may be called concurrently with
+// RunMiddleware, including after request serving has already started.
+func RegisterMiddleware(mw Middleware) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+	middleware = append(middleware, mw)
+}
+
+// RunMiddleware threads ctx through every registered Middleware in
+// registration order and returns the resulting context.
+func RunMiddleware(ctx context.Context, r *Request) context.Context {
+	middlewareMu.RLock()
+	chain := make([]Middleware, len(middleware))
+	copy(chain, middleware)
+	middlewareMu.RUnlock()
+
+	for _, mw := range chain {
+		ctx = mw(ctx, r)
+	}
+	return ctx
+}
@@ -0,0 +1,174 @@
+package logical
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// durationType lets decodeValue special-case time.Duration fields, which
+// are reflect.Int64 under the hood but whose string form ("5s") needs
+// time.ParseDuration rather than strconv.ParseInt.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// mapstructureWeakDecode decodes raw (expected to be a primitive value or a
+// map[string]interface{} as produced by JSON/HCL parsing) into out, a
+// pointer to the destination value. It performs the same weak type
+// coercion FieldData's typed getters do (e.g. numeric strings into ints),
+// mirroring mapstructure's WeaklyTypedInput mode closely enough for
+// decoding Request.Data without pulling in an external dependency.
+func mapstructureWeakDecode(raw interface{}, out interface{}) error {
+	outVal := reflect.ValueOf(out)
+	if outVal.Kind() != reflect.Ptr {
+		return fmt.Errorf("out must be a pointer, got %T", out)
+	}
+
+	return decodeValue(reflect.Indirect(outVal), raw)
+}
+
+func decodeValue(dst reflect.Value, raw interface{}) error {
+	if raw == nil {
+		return nil
+	}
+
+	rawVal := reflect.ValueOf(raw)
+
+	// Exact/assignable type match: let native Go values built directly
+	// in code (a []string, a map[string]string, a time.Duration) pass
+	// straight through, rather than only accepting the []interface{}/
+	// map[string]interface{} shape JSON/HCL unmarshaling produces.
+	if rawVal.Type().AssignableTo(dst.Type()) {
+		dst.Set(rawVal)
+		return nil
+	}
+
+	if dst.Type() == durationType {
+		switch v := raw.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return err
+			}
+			dst.Set(reflect.ValueOf(d))
+		case int:
+			dst.Set(reflect.ValueOf(time.Duration(v) * time.Second))
+		default:
+			return fmt.Errorf("cannot decode %T into time.Duration", raw)
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		switch v := raw.(type) {
+		case string:
+			dst.SetString(v)
+		default:
+			dst.SetString(fmt.Sprintf("%v", v))
+		}
+
+	case reflect.Bool:
+		switch v := raw.(type) {
+		case bool:
+			dst.SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			dst.SetBool(b)
+		default:
+			return fmt.Errorf("cannot decode %T into bool", raw)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := raw.(type) {
+		case string:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return err
+			}
+			dst.SetInt(n)
+		default:
+			if !rawVal.Type().ConvertibleTo(dst.Type()) {
+				return fmt.Errorf("cannot decode %T into %s", raw, dst.Type())
+			}
+			dst.SetInt(rawVal.Convert(dst.Type()).Int())
+		}
+
+	case reflect.Slice:
+		if rawVal.Kind() != reflect.Slice && rawVal.Kind() != reflect.Array {
+			return fmt.Errorf("cannot decode %T into slice", raw)
+		}
+		out := reflect.MakeSlice(dst.Type(), rawVal.Len(), rawVal.Len())
+		for i := 0; i < rawVal.Len(); i++ {
+			if err := decodeValue(out.Index(i), rawVal.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+
+	case reflect.Map:
+		if rawVal.Kind() != reflect.Map {
+			return fmt.Errorf("cannot decode %T into map", raw)
+		}
+
+		out := reflect.MakeMapWithSize(dst.Type(), rawVal.Len())
+		keyType, elemType := dst.Type().Key(), dst.Type().Elem()
+		iter := rawVal.MapRange()
+		for iter.Next() {
+			key := reflect.New(keyType).Elem()
+			if err := decodeValue(key, iter.Key().Interface()); err != nil {
+				return fmt.Errorf("error decoding map key %v: %w", iter.Key().Interface(), err)
+			}
+
+			elem := reflect.New(elemType).Elem()
+			if err := decodeValue(elem, iter.Value().Interface()); err != nil {
+				return fmt.Errorf("error decoding map value for key %v: %w", iter.Key().Interface(), err)
+			}
+			out.SetMapIndex(key, elem)
+		}
+		dst.Set(out)
+
+	case reflect.Struct:
+		rawMap, ok := raw.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot decode %T into struct", raw)
+		}
+		return decodeStruct(dst, rawMap)
+
+	default:
+		return fmt.Errorf("cannot decode %T into %s", raw, dst.Type())
+	}
+
+	return nil
+}
+
+func decodeStruct(dst reflect.Value, rawMap map[string]interface{}) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// Unexported field; reflect can't set it, and mapstructure
+			// itself skips these rather than erroring.
+			continue
+		}
+
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			name = field.Name
+		}
+
+		raw, ok := rawMap[name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeValue(dst.Field(i), raw); err != nil {
+			return fmt.Errorf("error decoding field %q: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package logical
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FieldType is an enum of the type of a field in a FieldSchema.
+type FieldType uint
+
+const (
+	TypeInvalid FieldType = iota
+	TypeString
+	TypeInt
+	TypeBool
+	TypeMap
+	TypeDuration
+	TypeStringSlice
+)
+
+func (t FieldType) String() string {
+	switch t {
+	case TypeString:
+		return "string"
+	case TypeInt:
+		return "int"
+	case TypeBool:
+		return "bool"
+	case TypeMap:
+		return "map"
+	case TypeDuration:
+		return "duration"
+	case TypeStringSlice:
+		return "string slice"
+	default:
+		return "unknown type"
+	}
+}
+
+// FieldSchema describes a single field accepted in Request.Data, used by
+// FieldData to validate and coerce raw request data before a backend ever
+// sees it.
+type FieldSchema struct {
+	Type          FieldType
+	Default       interface{}
+	Description   string
+	Required      bool
+	AllowedValues []interface{}
+}
+
+// DefaultOrZero returns the default value, or the zero value if no default
+// is set.
+func (s *FieldSchema) DefaultOrZero() interface{} {
+	if s.Default != nil {
+		return s.Default
+	}
+
+	switch s.Type {
+	case TypeString:
+		return ""
+	case TypeInt:
+		return 0
+	case TypeBool:
+		return false
+	case TypeMap:
+		return map[string]interface{}{}
+	case TypeDuration:
+		return time.Duration(0)
+	case TypeStringSlice:
+		return []string{}
+	default:
+		panic("unknown field type: " + s.Type.String())
+	}
+}
+
+// FieldData is a view over Request.Data validated and coerced against a
+// map of FieldSchema, keyed by field name.
+type FieldData struct {
+	Raw    map[string]interface{}
+	Schema map[string]*FieldSchema
+}
+
+// Validate checks the raw data against the schema, returning an
+// ErrInvalidRequest-wrapped error listing every missing required field or
+// value that fails to coerce into its declared type.
+func (d *FieldData) Validate() error {
+	var problems []string
+	for field, schema := range d.Schema {
+		value, ok := d.Raw[field]
+		if !ok {
+			if schema.Required {
+				problems = append(problems, fmt.Sprintf("missing required field %q", field))
+			}
+			continue
+		}
+
+		coerced, _, err := d.getPrimitive(field, value, schema)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("error converting field %q: %s", field, err))
+			continue
+		}
+
+		if len(schema.AllowedValues) > 0 && !allowed(coerced, schema.AllowedValues) {
+			problems = append(problems, fmt.Sprintf("value %v for field %q is not an allowed value", coerced, field))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w: %s", ErrInvalidRequest, strings.Join(problems, "; "))
+}
+
+// Get gets the value for the given field. If the key is an invalid field,
+// FieldData will panic, so it's expected that you only request fields
+// declared in the schema. If the field is not set, the schema default (or
+// type zero value) is returned.
+func (d *FieldData) Get(k string) interface{} {
+	schema, ok := d.Schema[k]
+	if !ok {
+		panic(fmt.Sprintf("field %q not in the schema", k))
+	}
+
+	value, _, err := d.GetOkErr(k)
+	if err != nil {
+		panic(fmt.Sprintf("error reading %s: %s", k, err))
+	}
+	if value == nil {
+		return schema.DefaultOrZero()
+	}
+
+	return value
+}
+
+// GetOk gets the value for the given field, returning whether it was set.
+func (d *FieldData) GetOk(k string) (interface{}, bool) {
+	result, ok, err := d.GetOkErr(k)
+	if err != nil {
+		return nil, false
+	}
+	return result, ok
+}
+
+// GetOkErr is the underlying implementation of GetOk that also returns
+// errors.
+func (d *FieldData) GetOkErr(k string) (interface{}, bool, error) {
+	schema, ok := d.Schema[k]
+	if !ok {
+		return nil, false, fmt.Errorf("unknown field: %q", k)
+	}
+
+	raw, ok := d.Raw[k]
+	if !ok {
+		return nil, false, nil
+	}
+
+	return d.getPrimitive(k, raw, schema)
+}
+
+func (d *FieldData) getPrimitive(k string, raw interface{}, schema *FieldSchema) (interface{}, bool, error) {
+	switch schema.Type {
+	case TypeString:
+		switch v := raw.(type) {
+		case string:
+			return v, true, nil
+		case fmt.Stringer:
+			return v.String(), true, nil
+		default:
+			return fmt.Sprintf("%v", raw), true, nil
+		}
+
+	case TypeInt:
+		var result int
+		if err := mapstructureWeakDecode(raw, &result); err != nil {
+			return nil, false, err
+		}
+		return result, true, nil
+
+	case TypeBool:
+		var result bool
+		if err := mapstructureWeakDecode(raw, &result); err != nil {
+			return nil, false, err
+		}
+		return result, true, nil
+
+	case TypeMap:
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("expected map, got %T", raw)
+		}
+		return v, true, nil
+
+	case TypeDuration:
+		switch v := raw.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return nil, false, err
+			}
+			return d, true, nil
+		case time.Duration:
+			return v, true, nil
+		case int:
+			return time.Duration(v) * time.Second, true, nil
+		default:
+			return nil, false, fmt.Errorf("expected duration, got %T", raw)
+		}
+
+	case TypeStringSlice:
+		return d.parseStringSlice(raw)
+
+	default:
+		return nil, false, fmt.Errorf("unknown field type %q", schema.Type)
+	}
+}
+
+func (d *FieldData) parseStringSlice(raw interface{}) (interface{}, bool, error) {
+	switch v := raw.(type) {
+	case []string:
+		return v, true, nil
+	case []interface{}:
+		result := make([]string, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, false, fmt.Errorf("expected string, got %T", e)
+			}
+			result[i] = s
+		}
+		return result, true, nil
+	default:
+		return nil, false, fmt.Errorf("expected string slice, got %T", raw)
+	}
+}
+
+// GetInt gets the value for the given field, interpreted as an int.
+func (d *FieldData) GetInt(k string) int {
+	return d.Get(k).(int)
+}
+
+// GetBool gets the value for the given field, interpreted as a bool.
+func (d *FieldData) GetBool(k string) bool {
+	return d.Get(k).(bool)
+}
+
+// GetDuration gets the value for the given field, interpreted as a
+// time.Duration.
+func (d *FieldData) GetDuration(k string) time.Duration {
+	return d.Get(k).(time.Duration)
+}
+
+// GetStringSlice gets the value for the given field, interpreted as a
+// []string.
+func (d *FieldData) GetStringSlice(k string) []string {
+	return d.Get(k).([]string)
+}
+
+// GetMap gets the value for the given field, interpreted as a
+// map[string]interface{}.
+func (d *FieldData) GetMap(k string) map[string]interface{} {
+	return d.Get(k).(map[string]interface{})
+}
+
+// Decode populates out, a pointer to a struct, by mapping Raw onto it field
+// by field, so a backend can write fd.Decode(&myStruct) instead of a
+// sequence of typed getters. Each exported field takes the Raw entry whose
+// key equals its mapstructure tag, or its Go field name when it has no tag;
+// keys are matched case-sensitively.
+func (d *FieldData) Decode(out interface{}) error {
+	return mapstructureWeakDecode(d.Raw, out)
+}
+
+func allowed(value interface{}, allowedValues []interface{}) bool {
+	for _, allowed := range allowedValues {
+		if reflect.DeepEqual(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package logical
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFieldDataDecodeTypedMap(t *testing.T) {
+	type target struct {
+		Tags map[string]string
+	}
+
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			"Tags": map[string]interface{}{
+				"a": "b",
+				"c": "d",
+			},
+		},
+	}
+
+	var out target
+	if err := fd.Decode(&out); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if out.Tags["a"] != "b" || out.Tags["c"] != "d" {
+		t.Fatalf("unexpected Tags: %#v", out.Tags)
+	}
+}
+
+func TestFieldDataDecodeSkipsUnexportedFields(t *testing.T) {
+	type target struct {
+		count int
+		Count int
+	}
+
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			"count": 5,
+			"Count": 7,
+		},
+	}
+
+	var out target
+	if err := fd.Decode(&out); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+
+	if out.count != 0 {
+		t.Fatalf("expected unexported field to be left untouched, got %d", out.count)
+	}
+	if out.Count != 7 {
+		t.Fatalf("expected exported field to decode, got %d", out.Count)
+	}
+}
+
+func TestFieldDataGetIntDoesNotTreatLeadingZeroAsOctal(t *testing.T) {
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			"count": "010",
+		},
+		Schema: map[string]*FieldSchema{
+			"count": {Type: TypeInt},
+		},
+	}
+
+	if got := fd.GetInt("count"); got != 10 {
+		t.Fatalf("GetInt(%q) = %d, want 10", "010", got)
+	}
+}
+
+func TestFieldDataValidateMissingRequired(t *testing.T) {
+	fd := &FieldData{
+		Raw: map[string]interface{}{},
+		Schema: map[string]*FieldSchema{
+			"name": {Type: TypeString, Required: true},
+		},
+	}
+
+	err := fd.Validate()
+	if err == nil {
+		t.Fatal("expected an error for a missing required field")
+	}
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected error to wrap ErrInvalidRequest, got %v", err)
+	}
+}
+
+func TestFieldDataValidateAllowedValues(t *testing.T) {
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			"color": "purple",
+		},
+		Schema: map[string]*FieldSchema{
+			"color": {Type: TypeString, AllowedValues: []interface{}{"red", "blue"}},
+		},
+	}
+
+	if err := fd.Validate(); !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected ErrInvalidRequest for disallowed value, got %v", err)
+	}
+}
+
+func TestFieldDataValidateAllowedValuesComparesCoercedValue(t *testing.T) {
+	fd := &FieldData{
+		// JSON decoding hands back float64 for every number; this must
+		// still match an AllowedValues list of ints once coerced.
+		Raw: map[string]interface{}{
+			"count": float64(2),
+		},
+		Schema: map[string]*FieldSchema{
+			"count": {Type: TypeInt, AllowedValues: []interface{}{1, 2, 3}},
+		},
+	}
+
+	if err := fd.Validate(); err != nil {
+		t.Fatalf("expected float64(2) to match coerced int 2, got %v", err)
+	}
+}
+
+func TestFieldDataDecodeNativeGoSlice(t *testing.T) {
+	type target struct {
+		Names []string
+	}
+
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			// Built directly in Go, not round-tripped through JSON, so
+			// this is a native []string rather than []interface{}.
+			"Names": []string{"a", "b"},
+		},
+	}
+
+	var out target
+	if err := fd.Decode(&out); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(out.Names) != 2 || out.Names[0] != "a" || out.Names[1] != "b" {
+		t.Fatalf("unexpected Names: %#v", out.Names)
+	}
+}
+
+func TestFieldDataDecodeNativeGoMap(t *testing.T) {
+	type target struct {
+		Tags map[string]string
+	}
+
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			"Tags": map[string]string{"a": "b"},
+		},
+	}
+
+	var out target
+	if err := fd.Decode(&out); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if out.Tags["a"] != "b" {
+		t.Fatalf("unexpected Tags: %#v", out.Tags)
+	}
+}
+
+func TestFieldDataDecodeDurationString(t *testing.T) {
+	type target struct {
+		Timeout time.Duration
+	}
+
+	fd := &FieldData{
+		Raw: map[string]interface{}{
+			"Timeout": "5s",
+		},
+	}
+
+	var out target
+	if err := fd.Decode(&out); err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if out.Timeout != 5*time.Second {
+		t.Fatalf("Timeout = %v, want 5s", out.Timeout)
+	}
+}
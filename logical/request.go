@@ -1,6 +1,7 @@
 package logical
 
 import (
+	"context"
 	"errors"
 )
 
@@ -26,6 +27,49 @@ type Request struct {
 	// Secret will be non-nil only for Revoke and Renew operations
 	// to represent the secret that was returned prior.
 	Secret *Secret
+
+	// TransactionID, if set, scopes this request to the transaction
+	// previously opened with that ID via BeginTransactionOperation. It is
+	// required on CommitTransactionOperation and
+	// RollbackTransactionOperation requests.
+	TransactionID string
+
+	// Batch holds the sub-requests of a BatchOperation request, dispatched
+	// and answered together according to BatchPolicy. It must be empty
+	// for every other Operation.
+	Batch []*Request
+
+	// BatchPolicy controls how Batch is dispatched. It is only consulted
+	// when Operation is BatchOperation.
+	BatchPolicy BatchPolicy
+
+	// ResumeToken, for a WatchOperation request, is the cursor of the
+	// last WatchEvent the caller saw. Leave empty to start the watch from
+	// the current state of the prefix.
+	ResumeToken string
+
+	// ctx carries the request's cancellation, deadline, and request-scoped
+	// values (request ID, auth principal, trace span). Use Context and
+	// WithContext to read and set it; it is unexported so callers never
+	// have to special-case a Request whose context was never set.
+	ctx context.Context
+}
+
+// Context returns the request's context. It is never nil: requests
+// created without WithContext default to context.Background().
+func (r *Request) Context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
+// WithContext returns a shallow copy of r with its context set to ctx.
+func (r *Request) WithContext(ctx context.Context) *Request {
+	r2 := new(Request)
+	*r2 = *r
+	r2.ctx = ctx
+	return r2
 }
 
 // Get returns a data field and guards for nil Data
@@ -73,6 +117,50 @@ func RollbackRequest(path string) *Request {
 	}
 }
 
+// BeginTransactionRequest creates the structure of a request that opens a
+// new transaction scoped to path. The handler's response is expected to
+// carry the transaction ID that subsequent requests must set on
+// TransactionID.
+func BeginTransactionRequest(path string) *Request {
+	return &Request{
+		Operation: BeginTransactionOperation,
+		Path:      path,
+	}
+}
+
+// CommitTransactionRequest creates the structure of a request that commits
+// every call made under transactionID.
+func CommitTransactionRequest(path, transactionID string) *Request {
+	return &Request{
+		Operation:     CommitTransactionOperation,
+		Path:          path,
+		TransactionID: transactionID,
+	}
+}
+
+// RollbackTransactionRequest creates the structure of a request that rolls
+// back every call made under transactionID. Rolling back an unknown or
+// already-expired transaction ID is a no-op and must return nil.
+func RollbackTransactionRequest(path, transactionID string) *Request {
+	return &Request{
+		Operation:     RollbackTransactionOperation,
+		Path:          path,
+		TransactionID: transactionID,
+	}
+}
+
+// ValidateTransaction returns ErrInvalidRequest if a transaction operation
+// is missing the transaction ID it requires.
+func (r *Request) ValidateTransaction() error {
+	switch r.Operation {
+	case CommitTransactionOperation, RollbackTransactionOperation:
+		if r.TransactionID == "" {
+			return ErrInvalidRequest
+		}
+	}
+	return nil
+}
+
 // Operation is an enum that is used to specify the type
 // of request being made
 type Operation string
@@ -89,6 +177,21 @@ const (
 	RevokeOperation   Operation = "revoke"
 	RenewOperation              = "renew"
 	RollbackOperation           = "rollback"
+
+	// The operations below group a series of requests into an atomic unit
+	// of work via Request.TransactionID.
+	BeginTransactionOperation    Operation = "begin-transaction"
+	CommitTransactionOperation             = "commit-transaction"
+	RollbackTransactionOperation           = "rollback-transaction"
+
+	// BatchOperation dispatches every sub-request in Request.Batch in a
+	// single round trip; see BatchPolicy for how failures are handled.
+	BatchOperation Operation = "batch"
+
+	// WatchOperation subscribes to changes under Path and streams them
+	// back as a WatchResponse rather than polling; see ResumeToken for
+	// resuming after a disconnect.
+	WatchOperation Operation = "watch"
 )
 
 var (
@@ -102,4 +205,13 @@ var (
 
 	// ErrInvalidRequest is returned if the request is invalid
 	ErrInvalidRequest = errors.New("invalid request")
+
+	// ErrTransactionAborted is returned when an operation is attempted
+	// against a transaction that has already been rolled back or
+	// otherwise aborted.
+	ErrTransactionAborted = errors.New("transaction aborted")
+
+	// ErrRequestTimeout is returned when a request's context is canceled
+	// or its deadline passes before a backend finishes handling it.
+	ErrRequestTimeout = errors.New("request timeout")
 )
@@ -0,0 +1,15 @@
+package logical
+
+// Response is a struct that stores the response of a request. It is used
+// to abstract the details of the higher level response protocol from the
+// handlers.
+type Response struct {
+	// Secret, if not nil, denotes that this response represents a secret.
+	Secret *Secret
+
+	// Data is the data for this response.
+	Data map[string]interface{}
+
+	// Redirect is an HTTP URL to redirect to for further authentication.
+	Redirect string
+}
@@ -0,0 +1,24 @@
+package logical
+
+import "time"
+
+// Secret represents the secret part of a Response. It is returned
+// whenever a backend hands back data that should be tracked for renewal
+// or revocation.
+type Secret struct {
+	LeaseOptions
+
+	// InternalData is JSON-encodable data that is stored with the secret
+	// and passed back on Revoke/Renew requests via Request.Secret.
+	InternalData map[string]interface{}
+}
+
+// LeaseOptions describes the lease associated with a Secret.
+type LeaseOptions struct {
+	// TTL is the duration this secret is valid for.
+	TTL time.Duration
+
+	// Renewable indicates whether the TTL can be extended via a renew
+	// operation.
+	Renewable bool
+}
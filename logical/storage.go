@@ -0,0 +1,38 @@
+package logical
+
+// Storage is the way that logical backends are able to durably
+// read/write state.
+type Storage interface {
+	List(prefix string) ([]string, error)
+	Get(string) (*StorageEntry, error)
+	Put(*StorageEntry) error
+	Delete(string) error
+}
+
+// StorageEntry is the entry for an item in a Storage implementation.
+type StorageEntry struct {
+	Key   string
+	Value []byte
+}
+
+// TransactionalStorage is implemented by Storage backends that can group a
+// series of Get/Put/Delete calls into a single atomic unit of work, keyed
+// by the transaction ID they hand back from Begin. Request.TransactionID
+// carries that ID on every call that should be folded into the open
+// transaction.
+type TransactionalStorage interface {
+	Storage
+
+	// Begin starts a new transaction and returns an ID that callers attach
+	// to subsequent requests via Request.TransactionID.
+	Begin() (string, error)
+
+	// Commit durably applies every call made under transactionID. Once
+	// Commit returns successfully the transaction ID is no longer valid.
+	Commit(transactionID string) error
+
+	// Rollback discards every call made under transactionID. Rolling back
+	// an unknown or already-expired transaction ID is a no-op and returns
+	// nil, mirroring the pattern used by transactional data APIs.
+	Rollback(transactionID string) error
+}
@@ -0,0 +1,10 @@
+package logical
+
+// Transactional is implemented by logical backends that can group a
+// series of requests into a single atomic unit of work via
+// Request.TransactionID. A backend that does not implement this
+// interface, or whose SupportsTransactions returns false, should have
+// BeginTransactionOperation rejected with ErrUnsupportedOperation.
+type Transactional interface {
+	SupportsTransactions() bool
+}
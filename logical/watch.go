@@ -0,0 +1,160 @@
+package logical
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrWatchOverflow is returned to a watcher whose consumer fell behind far
+// enough that events had to be dropped rather than buffered forever. The
+// watcher should resume with WatchEvent.Cursor from the last event it did
+// receive.
+var ErrWatchOverflow = errors.New("watch overflow")
+
+// WatchEventType distinguishes the kind of change a WatchEvent reports.
+type WatchEventType string
+
+const (
+	WatchEventPut    WatchEventType = "put"
+	WatchEventDelete WatchEventType = "delete"
+)
+
+// WatchEvent describes a single change under a watched path prefix.
+type WatchEvent struct {
+	Type WatchEventType
+	Key  string
+
+	// Version increases by one each time Key is put, including a
+	// recreate after a delete, letting a consumer distinguish a first
+	// put from a later one.
+	Version uint64
+
+	// Cursor is an opaque token identifying this event's position in the
+	// stream. Pass it back as Request.ResumeToken to resume after it.
+	Cursor string
+}
+
+// WatchResponse is returned for a WatchOperation request. Events arrives
+// in order; Errors carries ErrWatchOverflow if the consumer ever falls
+// behind far enough that events were dropped. Close stops the watch and
+// must be called once the consumer is done, even if Events was never
+// drained.
+type WatchResponse struct {
+	Events <-chan WatchEvent
+	Errors <-chan error
+	Close  func()
+}
+
+// Watcher is implemented by Storage backends, such as Consul or
+// etcd-backed stores, that can natively stream changes under a path
+// prefix instead of relying on the polling fallback in WatchPrefix.
+type Watcher interface {
+	// Watch streams changes under prefix, resuming after resumeToken if
+	// it is non-empty. The returned channel is closed, and any goroutine
+	// feeding it stopped, once the returned Close is called.
+	Watch(prefix, resumeToken string) (*WatchResponse, error)
+}
+
+// watchBufferSize bounds the channel WatchPrefix hands back; a consumer
+// that falls this far behind receives ErrWatchOverflow on Errors instead
+// of blocking the poller forever.
+const watchBufferSize = 64
+
+// WatchPrefix implements Watcher for any Storage by diffing List results
+// on a fixed interval. It is the fallback used when a Storage does not
+// implement Watcher natively. Because it has no event log to replay, it
+// cannot honor resumeToken the way a native Watcher can; resumeToken is
+// accepted for interface compatibility but every call starts from the
+// prefix's current state. Only keys that appear in or disappear from
+// successive List results are reported: overwriting a key that is already
+// present emits no event and does not change its Version.
+func WatchPrefix(s Storage, prefix, resumeToken string, interval time.Duration) (*WatchResponse, error) {
+	if w, ok := s.(Watcher); ok {
+		return w.Watch(prefix, resumeToken)
+	}
+
+	events := make(chan WatchEvent, watchBufferSize)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+
+	go pollForChanges(s, prefix, interval, events, errs, done)
+
+	closeOnce := make(chan struct{})
+	return &WatchResponse{
+		Events: events,
+		Errors: errs,
+		Close: func() {
+			select {
+			case <-closeOnce:
+			default:
+				close(closeOnce)
+				close(done)
+			}
+		},
+	}, nil
+}
+
+func pollForChanges(s Storage, prefix string, interval time.Duration, events chan<- WatchEvent, errs chan<- error, done <-chan struct{}) {
+	defer close(events)
+
+	present := map[string]bool{}
+
+	// versions is a per-key monotonic counter, bumped on every put
+	// (including a recreate after delete) and never reset, so it
+	// survives across delete/recreate cycles of the same key for as
+	// long as this poll loop runs.
+	versions := map[string]uint64{}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var cursor uint64
+	nextCursor := func() string {
+		cursor++
+		return strconv.FormatUint(cursor, 10)
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			keys, err := s.List(prefix)
+			if err != nil {
+				continue
+			}
+
+			current := make(map[string]bool, len(keys))
+			for _, key := range keys {
+				current[key] = true
+				if !present[key] {
+					versions[key]++
+					emit(events, errs, done, WatchEvent{Type: WatchEventPut, Key: key, Version: versions[key], Cursor: nextCursor()})
+				}
+			}
+			for key := range present {
+				if !current[key] {
+					emit(events, errs, done, WatchEvent{Type: WatchEventDelete, Key: key, Version: versions[key], Cursor: nextCursor()})
+				}
+			}
+			present = current
+		}
+	}
+}
+
+func emit(events chan<- WatchEvent, errs chan<- error, done <-chan struct{}, e WatchEvent) {
+	select {
+	case events <- e:
+	case <-done:
+	default:
+		// The consumer is lagging and the buffer is full; rather than
+		// block the poller indefinitely, drop the event and surface
+		// ErrWatchOverflow so the consumer knows to resume from its last
+		// cursor instead of silently missing updates.
+		select {
+		case errs <- ErrWatchOverflow:
+		default:
+		}
+	}
+}
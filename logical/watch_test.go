@@ -0,0 +1,138 @@
+package logical
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memStorage is a minimal in-memory Storage used to exercise the polling
+// watch fallback.
+type memStorage struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func newMemStorage() *memStorage {
+	return &memStorage{entries: map[string][]byte{}}
+}
+
+func (m *memStorage) List(prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var keys []string
+	for k := range m.entries {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (m *memStorage) Get(key string) (*StorageEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &StorageEntry{Key: key, Value: v}, nil
+}
+
+func (m *memStorage) Put(e *StorageEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[e.Key] = e.Value
+	return nil
+}
+
+func (m *memStorage) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+func awaitEvent(t *testing.T, events <-chan WatchEvent) WatchEvent {
+	t.Helper()
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+		return WatchEvent{}
+	}
+}
+
+func TestWatchPrefixVersionsSurvivePutDeleteRecreate(t *testing.T) {
+	s := newMemStorage()
+
+	resp, err := WatchPrefix(s, "secret/", "", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchPrefix returned error: %v", err)
+	}
+	defer resp.Close()
+
+	if err := s.Put(&StorageEntry{Key: "secret/foo"}); err != nil {
+		t.Fatal(err)
+	}
+	put1 := awaitEvent(t, resp.Events)
+	if put1.Type != WatchEventPut || put1.Key != "secret/foo" || put1.Version != 1 {
+		t.Fatalf("unexpected first put event: %#v", put1)
+	}
+
+	if err := s.Delete("secret/foo"); err != nil {
+		t.Fatal(err)
+	}
+	del := awaitEvent(t, resp.Events)
+	if del.Type != WatchEventDelete || del.Key != "secret/foo" || del.Version != 1 {
+		t.Fatalf("unexpected delete event: %#v", del)
+	}
+
+	if err := s.Put(&StorageEntry{Key: "secret/foo"}); err != nil {
+		t.Fatal(err)
+	}
+	put2 := awaitEvent(t, resp.Events)
+	if put2.Type != WatchEventPut || put2.Key != "secret/foo" || put2.Version != 2 {
+		t.Fatalf("expected recreated key to carry version 2, got %#v", put2)
+	}
+}
+
+func TestEmitOverflowsWhenConsumerLags(t *testing.T) {
+	events := make(chan WatchEvent, 1)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+
+	// Fill the buffered channel so the next emit has nowhere to put its
+	// event.
+	emit(events, errs, done, WatchEvent{Key: "a"})
+	emit(events, errs, done, WatchEvent{Key: "b"})
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrWatchOverflow) {
+			t.Fatalf("expected ErrWatchOverflow, got %v", err)
+		}
+	default:
+		t.Fatal("expected an overflow error to be sent on errs")
+	}
+
+	// The first event should still be readable; the second was dropped.
+	first := <-events
+	if first.Key != "a" {
+		t.Fatalf("expected surviving event to be %q, got %q", "a", first.Key)
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("expected no further events, got %#v", e)
+	default:
+	}
+}